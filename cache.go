@@ -0,0 +1,66 @@
+package remarshal
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// structInfo holds the reflect-derived metadata for a struct type: its
+// regex_group fields, a tag-value -> field index for O(1) lookups, and any
+// conflict error detected while walking the type (e.g. two tags pointing at
+// the same group), so it surfaces deterministically on every call instead
+// of being rediscovered by re-scanning.
+type structInfo struct {
+	fields []*field
+	byTag  map[string]*field
+	err    error
+}
+
+// typeCache maps reflect.Type to *structInfo. It is safe for concurrent use.
+var typeCache sync.Map
+
+// structInfoFor returns the cached structInfo for typeOf, computing and
+// storing it on first use.
+func structInfoFor(typeOf reflect.Type) *structInfo {
+	if cached, ok := typeCache.Load(typeOf); ok {
+		return cached.(*structInfo)
+	}
+
+	fields, err := computeFields(typeOf)
+	info := &structInfo{
+		fields: fields,
+		err:    err,
+		byTag:  make(map[string]*field, len(fields)),
+	}
+	for _, f := range fields {
+		info.byTag[f.GetTagValue()] = f
+	}
+
+	actual, _ := typeCache.LoadOrStore(typeOf, info)
+	return actual.(*structInfo)
+}
+
+// ClearTypeCache discards all cached struct metadata. Tests that redefine
+// anonymous struct types across cases can call this to avoid stale entries;
+// regular callers never need it.
+func ClearTypeCache() {
+	typeCache = sync.Map{}
+}
+
+// PrewarmType populates the type cache for v's struct type ahead of time, so
+// the first real RegexUnmarshal/Marshal call against it doesn't pay for the
+// reflect walk. v may be a struct, a pointer to one, or a nil pointer of the
+// right type (e.g. PrewarmType((*MyStruct)(nil))); servers can call this for
+// their known request/response types at startup. It returns the same
+// conflict error NewWorker/Marshal would surface for that type, if any.
+func PrewarmType(v interface{}) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return errors.New("the value to prewarm is not a struct nor a pointer to one")
+	}
+	return structInfoFor(t).err
+}
@@ -0,0 +1,88 @@
+package remarshal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStructInfoIsCached(t *testing.T) {
+	ClearTypeCache()
+
+	type Sample struct {
+		Smth string `regex_group:"Something"`
+	}
+	typeOf := reflect.TypeOf(Sample{})
+
+	first := structInfoFor(typeOf)
+	second := structInfoFor(typeOf)
+	if first != second {
+		t.Fatal("expected the same cached structInfo to be returned on repeat lookups")
+	}
+}
+
+func TestClearTypeCache(t *testing.T) {
+	type Sample struct {
+		Smth string `regex_group:"Something"`
+	}
+	typeOf := reflect.TypeOf(Sample{})
+
+	before := structInfoFor(typeOf)
+	ClearTypeCache()
+	after := structInfoFor(typeOf)
+	if before == after {
+		t.Fatal("expected ClearTypeCache to force recomputation of structInfo")
+	}
+}
+
+func TestPrewarmType(t *testing.T) {
+	ClearTypeCache()
+
+	type Sample struct {
+		Smth string `regex_group:"Something"`
+	}
+	if err := PrewarmType((*Sample)(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	typeOf := reflect.TypeOf(Sample{})
+	if _, ok := typeCache.Load(typeOf); !ok {
+		t.Fatal("expected PrewarmType to populate the type cache")
+	}
+}
+
+func TestPrewarmTypeRejectsNonStruct(t *testing.T) {
+	if err := PrewarmType(42); err == nil {
+		t.Fatal("expected an error for a non-struct value")
+	}
+}
+
+// big20Fields is used by BenchmarkStructInfoFor to measure the cache's
+// effect on a struct large enough that the reflect walk isn't free.
+type big20Fields struct {
+	F1, F2, F3, F4, F5, F6, F7, F8, F9, F10          string
+	F11, F12, F13, F14, F15, F16, F17, F18, F19, F20 string
+}
+
+// BenchmarkStructInfoFor compares a cold structInfoFor call (as if the type
+// cache were cleared before every lookup) against a warm one. Run with
+// `go test -bench StructInfoFor -benchtime 100000x` to reproduce the
+// 100k-iteration comparison this cache was built to satisfy.
+func BenchmarkStructInfoFor(b *testing.B) {
+	typeOf := reflect.TypeOf(big20Fields{})
+
+	b.Run("cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ClearTypeCache()
+			structInfoFor(typeOf)
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		ClearTypeCache()
+		structInfoFor(typeOf)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			structInfoFor(typeOf)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package remarshal
+
+import (
+	"encoding"
+	"reflect"
+	"sync"
+)
+
+// Decoder lets a field's own type self-deserialize from the raw captured
+// string. It takes priority over everything else convertScalar tries: a
+// Worker's registered Parsers, the package-level decoder registry, the
+// TextUnmarshaler/BinaryUnmarshaler fallback, and the built-in type switch.
+type Decoder interface {
+	DecodeRegexValue(string) error
+}
+
+var (
+	decoderType           = reflect.TypeOf((*Decoder)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// decoderRegistry holds package-wide parsers installed via RegisterDecoder,
+// keyed by the field type they handle.
+var decoderRegistry sync.Map // map[reflect.Type]func(string) (reflect.Value, error)
+
+// RegisterDecoder installs a package-wide parser for fieldType, for types
+// remarshal doesn't know about and callers don't own, so can't implement
+// Decoder on directly: time.Time, time.Duration, net.IP, url.URL, *big.Int,
+// third-party enums, and so on. Consulted after a Worker's own WithParser
+// entries but before the TextUnmarshaler/BinaryUnmarshaler fallback and the
+// built-in type switch. Safe for concurrent use.
+func RegisterDecoder(fieldType reflect.Type, decode func(string) (reflect.Value, error)) {
+	decoderRegistry.Store(fieldType, decode)
+}
+
+func registeredDecoder(fieldType reflect.Type) (func(string) (reflect.Value, error), bool) {
+	decode, ok := decoderRegistry.Load(fieldType)
+	if !ok {
+		return nil, false
+	}
+	return decode.(func(string) (reflect.Value, error)), true
+}
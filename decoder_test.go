@@ -0,0 +1,52 @@
+package remarshal
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type upperString string
+
+func (u *upperString) DecodeRegexValue(raw string) error {
+	*u = upperString(strings.ToUpper(raw))
+	return nil
+}
+
+func TestFieldDecoderTakesPriority(t *testing.T) {
+	v := &struct {
+		Name upperString `regex_group:"Name"`
+	}{}
+	if err := RegexUnmarshal("hello", regexp.MustCompile(`^(?P<Name>.*)$`), v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "HELLO" {
+		t.Fatalf("expected HELLO, got %q", v.Name)
+	}
+}
+
+type hexID uint32
+
+func TestRegisterDecoder(t *testing.T) {
+	defer func() { decoderRegistry = sync.Map{} }()
+	RegisterDecoder(reflect.TypeOf(hexID(0)), func(raw string) (reflect.Value, error) {
+		var n uint32
+		if _, err := fmt.Sscanf(raw, "%x", &n); err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(hexID(n)), nil
+	})
+
+	v := &struct {
+		ID hexID `regex_group:"ID"`
+	}{}
+	if err := RegexUnmarshal("1f", regexp.MustCompile(`^(?P<ID>.*)$`), v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != 31 {
+		t.Fatalf("expected 31, got %d", v.ID)
+	}
+}
@@ -0,0 +1,38 @@
+package remarshal
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Encoder lets a field's own type self-stringify during Marshal. It takes
+// priority over the package-level encoder registry, encoding.TextMarshaler,
+// and the built-in type switch in stringifyValue; the Marshal-side
+// counterpart to Decoder.
+type Encoder interface {
+	EncodeRegexValue() (string, error)
+}
+
+var encoderType = reflect.TypeOf((*Encoder)(nil)).Elem()
+
+// encoderRegistry holds package-wide stringifiers installed via
+// RegisterEncoder, keyed by the field type they handle.
+var encoderRegistry sync.Map // map[reflect.Type]func(reflect.Value) (string, error)
+
+// RegisterEncoder installs a package-wide stringifier for fieldType, the
+// Marshal-side counterpart to RegisterDecoder, for types remarshal doesn't
+// know about and callers don't own, so can't implement Encoder on directly.
+// Consulted after a field's own Encoder implementation but before
+// encoding.TextMarshaler and the built-in type switch. Safe for concurrent
+// use.
+func RegisterEncoder(fieldType reflect.Type, encode func(reflect.Value) (string, error)) {
+	encoderRegistry.Store(fieldType, encode)
+}
+
+func registeredEncoder(fieldType reflect.Type) (func(reflect.Value) (string, error), bool) {
+	encode, ok := encoderRegistry.Load(fieldType)
+	if !ok {
+		return nil, false
+	}
+	return encode.(func(reflect.Value) (string, error)), true
+}
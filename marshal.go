@@ -0,0 +1,235 @@
+package remarshal
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// Marshal reconstructs a string from v, the reverse of RegexUnmarshal. v's
+// regex_group-tagged fields are stringified into a map[string]string (group
+// name => value) which is then rendered according to format:
+//
+//   - a string is parsed as a text/template with {{.GroupName}} placeholders
+//   - a func(map[string]string) (string, error) receives the map directly
+//   - a *template.Template is executed directly against the map, for
+//     callers that already parsed their template (see TemplateMarshal)
+//   - a *regexp.Regexp is walked group by group, substituting each named
+//     capture with its value and reproducing the literal text around them;
+//     quantifiers and character classes outside named groups are left out
+//     (see marshalRegexLiteral), so the result is a string the regex itself
+//     would match only when the text around its named groups is literal
+func Marshal(v interface{}, format interface{}) (string, error) {
+	values, err := groupValues(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch f := format.(type) {
+	case string:
+		tmpl, err := template.New("remarshal").Parse(f)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, values); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case *template.Template:
+		var buf bytes.Buffer
+		if err := f.Execute(&buf, values); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case func(map[string]string) (string, error):
+		return f(values)
+	case *regexp.Regexp:
+		return marshalRegexLiteral(f, values)
+	default:
+		return "", fmt.Errorf("type %T is not valid as a Marshal format", format)
+	}
+}
+
+// RegexMarshal is Marshal's dedicated entry point for *regexp.Regexp
+// formats, the write-side counterpart to RegexUnmarshal.
+func RegexMarshal(v interface{}, re *regexp.Regexp) (string, error) {
+	return Marshal(v, re)
+}
+
+// TemplateMarshal is Marshal's dedicated entry point for pre-compiled
+// *template.Template formats, so callers that render the same template
+// repeatedly don't pay template.Parse's cost on every call.
+func TemplateMarshal(v interface{}, tmpl *template.Template) (string, error) {
+	return Marshal(v, tmpl)
+}
+
+// marshalRegexLiteral walks re's parsed syntax tree and reproduces the
+// literal text it matches, substituting each named capture group's value
+// from values in place of the pattern that group matched. This only makes
+// sense for regexes built from literals and named groups concatenated in
+// order (the shapes this package's tags expect); quantifiers and character
+// classes outside a named group carry no single literal value to
+// reconstruct, so they're skipped rather than guessed at. Unnamed capture
+// groups and alternations have no single literal value either, but unlike
+// those they're reported as errors rather than silently dropped, since a
+// caller relying on them would otherwise get a silently wrong string back.
+func marshalRegexLiteral(re *regexp.Regexp, values map[string]string) (string, error) {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := writeRegexLiteral(&buf, parsed, values); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeRegexLiteral(buf *bytes.Buffer, node *syntax.Regexp, values map[string]string) error {
+	switch node.Op {
+	case syntax.OpCapture:
+		if node.Name == "" {
+			return fmt.Errorf("regex has an unnamed capture group %q: marshaling requires every group to be named", node)
+		}
+		value, ok := values[node.Name]
+		if !ok {
+			return fmt.Errorf("regex group %q has no matching regex_group-tagged field", node.Name)
+		}
+		buf.WriteString(value)
+		return nil
+	case syntax.OpLiteral:
+		buf.WriteString(string(node.Rune))
+		return nil
+	case syntax.OpConcat:
+		return writeRegexLiteralChildren(buf, node.Sub, values)
+	case syntax.OpAlternate:
+		return fmt.Errorf("regex has an alternation %q: marshaling can't pick a single literal branch to reconstruct", node)
+	default:
+		// Anchors and empty matches are zero-width; quantifiers and
+		// character classes outside a named group have no literal value
+		// of their own. Either way there's nothing to write.
+		return nil
+	}
+}
+
+func writeRegexLiteralChildren(buf *bytes.Buffer, nodes []*syntax.Regexp, values map[string]string) error {
+	for _, sub := range nodes {
+		if err := writeRegexLiteral(buf, sub, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupValues walks v's regex_group-tagged fields, the same way lookupFields
+// does for unmarshaling, and stringifies each value into a group => value
+// map.
+func groupValues(v interface{}) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("the value to marshal is not a struct nor a pointer to one")
+	}
+
+	fields, err := lookupFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		fv, ok := readFieldValue(rv, f.path)
+		if !ok {
+			// a nil pointer somewhere along the path: nothing to marshal
+			values[f.GetTagValue()] = ""
+			continue
+		}
+		str, err := stringifyValue(fv, f.split)
+		if err != nil {
+			return nil, err
+		}
+		values[f.GetTagValue()] = str
+	}
+	return values, nil
+}
+
+// readFieldValue is groupValues' read-only counterpart to Worker.fieldValue:
+// it walks path from root without allocating, reporting ok=false if a nil
+// pointer is encountered along the way.
+func readFieldValue(root reflect.Value, path []int) (value reflect.Value, ok bool) {
+	value = root
+	for _, idx := range path {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(idx)
+	}
+	return value, true
+}
+
+// stringifyValue is the inverse of the type switch in convertScalar: it turns
+// a reflect.Value back into the string it was presumably parsed from. A
+// value whose type implements Encoder, or has an encoder installed via
+// RegisterEncoder, is stringified through that instead; see Decoder for the
+// read-side equivalent. For slice fields, elements are joined with sep (the
+// field's split tag option, defaulting to ",").
+func stringifyValue(value reflect.Value, sep string) (string, error) {
+	if value.CanInterface() && value.Type().Implements(encoderType) {
+		return value.Interface().(Encoder).EncodeRegexValue()
+	}
+
+	if encode, ok := registeredEncoder(value.Type()); ok {
+		return encode(value)
+	}
+
+	if value.CanInterface() && value.Type().Implements(textMarshalerType) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Slice:
+		if sep == "" {
+			sep = ","
+		}
+		parts := make([]string, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			part, err := stringifyValue(value.Index(i), "")
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		return strings.Join(parts, sep), nil
+	default:
+		return "", fmt.Errorf("field's type '%s' unknown, can't be marshaled back to a string", value.Kind())
+	}
+}
@@ -0,0 +1,138 @@
+package remarshal
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+)
+
+func (u upperString) EncodeRegexValue() (string, error) {
+	return strings.ToUpper(string(u)), nil
+}
+
+func TestMarshalTemplate(t *testing.T) {
+	v := &struct {
+		One string `regex_group:"first"`
+		Two string
+	}{One: "hello", Two: "world"}
+
+	out, err := Marshal(v, "{{.first}}-{{.Two}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "hello-world" {
+		t.Fatalf("unexpected marshal output: %q", out)
+	}
+}
+
+func TestMarshalFunc(t *testing.T) {
+	v := &struct {
+		Host, Port string
+	}{Host: "localhost", Port: "12345"}
+
+	out, err := Marshal(v, func(values map[string]string) (string, error) {
+		return values["Host"] + ":" + values["Port"], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "localhost:12345" {
+		t.Fatalf("unexpected marshal output: %q", out)
+	}
+}
+
+func ExampleRegexMarshal() {
+	v := &struct {
+		One   string `regex_group:"first"`
+		Two   string
+		Three string `regex_group:"Two"`
+	}{One: "a", Two: "b", Three: "c"}
+	re := regexp.MustCompile(`^(?P<first>.*)\|(?P<Two>.*)$`)
+
+	out, err := RegexMarshal(v, re)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(out)
+	// Output: a|c
+}
+
+func TestTemplateMarshal(t *testing.T) {
+	v := &struct {
+		One string `regex_group:"first"`
+		Two string
+	}{One: "hello", Two: "world"}
+
+	tmpl, err := template.New("t").Parse("{{.first}}-{{.Two}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := TemplateMarshal(v, tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "hello-world" {
+		t.Fatalf("unexpected marshal output: %q", out)
+	}
+}
+
+func TestMarshalWithEncoder(t *testing.T) {
+	v := &struct {
+		Name upperString
+	}{Name: "hello"}
+
+	out, err := Marshal(v, "{{.Name}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "HELLO" {
+		t.Fatalf("unexpected marshal output: %q", out)
+	}
+}
+
+func TestRegexMarshalUnnamedGroup(t *testing.T) {
+	v := &struct {
+		One string `regex_group:"first"`
+	}{One: "a"}
+	re := regexp.MustCompile(`^(\d+)-(?P<first>.*)$`)
+
+	_, err := RegexMarshal(v, re)
+	if err == nil {
+		t.Fatal("expected an error for an unnamed capture group")
+	}
+}
+
+func TestRegexMarshalAlternation(t *testing.T) {
+	v := &struct {
+		One string `regex_group:"a"`
+	}{One: "x"}
+	re := regexp.MustCompile(`^(?:foo|(?P<a>.*))$`)
+
+	_, err := RegexMarshal(v, re)
+	if err == nil {
+		t.Fatal("expected an error for an alternation")
+	}
+}
+
+func TestMarshalWithRegisteredEncoder(t *testing.T) {
+	defer func() { encoderRegistry = sync.Map{} }()
+	RegisterEncoder(reflect.TypeOf(hexID(0)), func(value reflect.Value) (string, error) {
+		return fmt.Sprintf("%x", uint32(value.Uint())), nil
+	})
+
+	v := &struct {
+		ID hexID
+	}{ID: 31}
+
+	out, err := Marshal(v, "{{.ID}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "1f" {
+		t.Fatalf("unexpected marshal output: %q", out)
+	}
+}
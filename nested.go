@@ -0,0 +1,234 @@
+package remarshal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// nestedGroupSeparator joins a nested field's own tag/name onto its parent's
+// regex group (e.g. "Addr" + "City" => "Addr_City"); Go's regexp package
+// disallows dots in named capture groups, so dotted display names (see
+// field.dottedName) can't be used as-is for matching.
+const nestedGroupSeparator = "_"
+
+func joinGroup(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + nestedGroupSeparator + name
+}
+
+func joinDotted(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// isNestableStruct reports whether fieldType, a struct field's declared type
+// (pointer or not), should be walked as a nested struct rather than treated
+// as a leaf value. time.Time and net.IPNet are structs but are handled
+// wholesale by the built-in Parsers instead, and so is any struct type a
+// built-in Parser, the decoder registry, Decoder, or
+// TextUnmarshaler/BinaryUnmarshaler already knows how to convert as a
+// whole (e.g. *url.URL, *big.Int), checked first against fieldType itself
+// and then, if it's a pointer, against its pointee. Parsers registered on a
+// Worker via WithParser are not consulted, so a struct type that only such
+// a parser handles is still walked as a nested struct.
+func isNestableStruct(fieldType reflect.Type) bool {
+	if isConvertibleLeafType(fieldType) {
+		return false
+	}
+	if fieldType.Kind() == reflect.Ptr && isConvertibleLeafType(fieldType.Elem()) {
+		return false
+	}
+	return true
+}
+
+func isConvertibleLeafType(t reflect.Type) bool {
+	if t == timeType || t == ipNetType {
+		return true
+	}
+	if builtinParserTypes[t] {
+		return true
+	}
+	if _, ok := registeredDecoder(t); ok {
+		return true
+	}
+	if reflect.PtrTo(t).Implements(decoderType) {
+		return true
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) || reflect.PtrTo(t).Implements(binaryUnmarshalerType) {
+		return true
+	}
+	return false
+}
+
+// tagOptions is the parsed form of a regex_group tag value, beyond the
+// group/field name itself.
+type tagOptions struct {
+	name string
+	// split, when non-empty, is the separator a slice field's captured
+	// string should be split on (regex_group:"Tags,split=,").
+	split string
+	// required makes NewWorker error when the group is missing from the
+	// pattern or matches an empty value (regex_group:"Name,required").
+	required bool
+	// optional suppresses the "not found in your pattern" error when the
+	// group is missing from the pattern (regex_group:"Name,optional").
+	optional bool
+	// hasDefault/defaultValue apply defaultValue when the group is missing
+	// from the pattern (regex_group:"Name,default=foo"); implies optional.
+	hasDefault   bool
+	defaultValue string
+}
+
+// parseTagOptions splits a regex_group tag value into its group/field name
+// and any trailing comma-separated options: "required", "optional", and
+// "default=...". "split=..." is also recognized, and since its value may
+// itself contain a comma (regex_group:"Tags,split=,"), it runs to the end
+// of the tag and ends parsing.
+func parseTagOptions(raw string) (opts tagOptions) {
+	parts := strings.SplitN(raw, ",", 2)
+	opts.name = parts[0]
+	if len(parts) != 2 {
+		return opts
+	}
+
+	rest := parts[1]
+	for rest != "" {
+		next := strings.SplitN(rest, ",", 2)
+		token := next[0]
+		switch {
+		case token == "required":
+			opts.required = true
+		case token == "optional":
+			opts.optional = true
+		case strings.HasPrefix(token, "default="):
+			opts.defaultValue = strings.TrimPrefix(token, "default=")
+			opts.hasDefault = true
+		case strings.HasPrefix(token, "split="):
+			opts.split = strings.TrimPrefix(rest, "split=")
+			return opts
+		}
+		if len(next) == 2 {
+			rest = next[1]
+		} else {
+			rest = ""
+		}
+	}
+	return opts
+}
+
+// fieldValue walks path (a chain of struct-field indices from the root
+// value down to a single field), allocating any nil pointer-to-struct
+// encountered along the way.
+func (worker *Worker) fieldValue(path []int) (reflect.Value, error) {
+	value := worker.reflectValue.Elem()
+	for _, idx := range path {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !value.CanSet() {
+					return reflect.Value{}, fmt.Errorf("can't allocate nil pointer while reaching a nested field")
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(idx)
+	}
+	return value, nil
+}
+
+// convertScalar assigns raw to target, which may be a scalar, a pointer to
+// one, or any type handled by a registered/built-in parser. A field
+// implementing Decoder on its pointer type takes priority over everything
+// else; then custom conversions are tried against the field's own type (so
+// e.g. a *big.Int parser can match a *big.Int field directly); only when
+// that declines and the type is a pointer do we allocate and recurse into
+// the pointee, which also lets standard *T encoding.TextUnmarshaler
+// implementations apply.
+func (worker *Worker) convertScalar(target reflect.Value, targetType reflect.Type, raw string) error {
+	if target.CanAddr() && reflect.PtrTo(targetType).Implements(decoderType) {
+		return target.Addr().Interface().(Decoder).DecodeRegexValue(raw)
+	}
+
+	if handled, err := worker.applyCustomConversion(target, targetType, raw); handled {
+		return err
+	}
+
+	if targetType.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(targetType.Elem()))
+		}
+		return worker.convertScalar(target.Elem(), targetType.Elem(), raw)
+	}
+
+	switch targetType.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		converted, err := strconv.ParseInt(raw, 0, strconv.IntSize)
+		if err != nil {
+			return fmt.Errorf("can't be converted to int: %w", err)
+		}
+		target.SetInt(converted)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		converted, err := strconv.ParseUint(raw, 0, strconv.IntSize)
+		if err != nil {
+			return fmt.Errorf("can't be converted to uint: %w", err)
+		}
+		target.SetUint(converted)
+	case reflect.Float32, reflect.Float64:
+		converted, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("can't be converted to float: %w", err)
+		}
+		target.SetFloat(converted)
+	case reflect.Bool:
+		converted, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("can't be converted to bool: %w", err)
+		}
+		target.SetBool(converted)
+	default:
+		return fmt.Errorf("field's type '%s' unknown", targetType)
+	}
+	return nil
+}
+
+// setSliceFromSplit splits raw on sep and converts each part into an
+// element of target's slice type, appending them in order.
+func (worker *Worker) setSliceFromSplit(target reflect.Value, sliceType reflect.Type, raw, sep string) error {
+	return worker.setSliceFromValues(target, sliceType, strings.Split(raw, sep))
+}
+
+// setSliceFromValues converts each of values into an element of target's
+// slice type, appending them in order. Used both for split-tagged slice
+// fields and for plain slice fields fed by a repeatedly-matching regex (see
+// Worker.RepeatedValues).
+func (worker *Worker) setSliceFromValues(target reflect.Value, sliceType reflect.Type, values []string) error {
+	elemType := sliceType.Elem()
+	out := reflect.MakeSlice(sliceType, 0, len(values))
+	for _, value := range values {
+		elem := reflect.New(elemType).Elem()
+		if err := worker.convertScalar(elem, elemType, value); err != nil {
+			return err
+		}
+		out = reflect.Append(out, elem)
+	}
+	target.Set(out)
+	return nil
+}
+
+// WithMaxMatches bounds how many times the regex may match repeatedly
+// against the input (see Worker.RepeatedValues), limiting how long slice
+// fields fed by repeated matches can grow. Zero, the default, means
+// unlimited.
+func WithMaxMatches(n int) Option {
+	return func(w *Worker) {
+		w.maxMatches = n
+	}
+}
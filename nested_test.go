@@ -0,0 +1,103 @@
+package remarshal
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNestedStructField(t *testing.T) {
+	v := &struct {
+		Name string
+		Addr struct {
+			City string
+		}
+	}{}
+	re := regexp.MustCompile(`^(?P<Name>[^|]*)\|(?P<Addr_City>.*)$`)
+
+	if err := RegexUnmarshal("Bob|Springfield", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "Bob" || v.Addr.City != "Springfield" {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestPointerToStructField(t *testing.T) {
+	type Address struct{ City string }
+	v := &struct {
+		Addr *Address
+	}{}
+	re := regexp.MustCompile(`^(?P<Addr_City>.*)$`)
+
+	if err := RegexUnmarshal("Springfield", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Addr == nil || v.Addr.City != "Springfield" {
+		t.Fatalf("got %+v", v.Addr)
+	}
+}
+
+func TestPointerToScalarField(t *testing.T) {
+	v := &struct {
+		Age *int
+	}{}
+	re := regexp.MustCompile(`^(?P<Age>.*)$`)
+
+	if err := RegexUnmarshal("42", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Age == nil || *v.Age != 42 {
+		t.Fatalf("got %v", v.Age)
+	}
+}
+
+func TestEmbeddedStructIsPromoted(t *testing.T) {
+	type Address struct{ City string }
+	v := &struct {
+		Name string
+		Address
+	}{}
+	re := regexp.MustCompile(`^(?P<Name>[^|]*)\|(?P<City>.*)$`)
+
+	if err := RegexUnmarshal("Bob|Springfield", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "Bob" || v.City != "Springfield" {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestTaggedEmbeddedStructIsQualified(t *testing.T) {
+	type Address struct{ City string }
+	v := &struct {
+		Address `regex_group:"Addr"`
+	}{}
+	re := regexp.MustCompile(`^(?P<Addr_City>.*)$`)
+
+	if err := RegexUnmarshal("Springfield", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.City != "Springfield" {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestSliceFieldWithSplit(t *testing.T) {
+	v := &struct {
+		Tags []string `regex_group:"Tags,split=,"`
+	}{}
+	re := regexp.MustCompile(`^(?P<Tags>.*)$`)
+
+	if err := RegexUnmarshal("a,b,c", re, v); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(v.Tags) != len(want) {
+		t.Fatalf("got %v", v.Tags)
+	}
+	for i := range want {
+		if v.Tags[i] != want[i] {
+			t.Fatalf("got %v, want %v", v.Tags, want)
+		}
+	}
+}
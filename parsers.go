@@ -0,0 +1,170 @@
+package remarshal
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// Parsers maps a field's reflect.Type to a function turning the raw
+// captured string into a value of that type.
+type Parsers map[reflect.Type]func(string) (interface{}, error)
+
+// Option configures a Worker created via NewWorker/RegexUnmarshalWith.
+type Option func(*Worker)
+
+// WithParser registers a custom parser for fieldType. Registered parsers
+// take priority over the package's built-in ones (time.Time, time.Duration,
+// net.IP, net.IPNet, *url.URL, *big.Int). A field whose type is a struct (or
+// a pointer to one) that the package doesn't already convert as a whole is
+// walked as a nested struct, so a parser registered for that type is not
+// used.
+func WithParser(fieldType reflect.Type, parse func(string) (interface{}, error)) Option {
+	return func(w *Worker) {
+		if w.Parsers == nil {
+			w.Parsers = Parsers{}
+		}
+		w.Parsers[fieldType] = parse
+	}
+}
+
+// WithTimeLayout overrides the layout used by the built-in time.Time
+// parser. Defaults to time.RFC3339.
+func WithTimeLayout(layout string) Option {
+	return func(w *Worker) {
+		w.timeLayout = layout
+	}
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	ipType              = reflect.TypeOf(net.IP{})
+	ipNetType           = reflect.TypeOf(net.IPNet{})
+	urlType             = reflect.TypeOf(&url.URL{})
+	bigIntType          = reflect.TypeOf(&big.Int{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// builtinParserTypes is the static key set of builtinParsers, independent of
+// any Worker's timeLayout override; isNestableStruct uses it to recognize a
+// struct-shaped field (e.g. *url.URL, *big.Int) as a leaf value without
+// needing a Worker to ask.
+var builtinParserTypes = map[reflect.Type]bool{
+	timeType:     true,
+	durationType: true,
+	ipType:       true,
+	ipNetType:    true,
+	urlType:      true,
+	bigIntType:   true,
+}
+
+// builtinParsers returns the package's default parsers, honoring any
+// WithTimeLayout override.
+func (worker *Worker) builtinParsers() Parsers {
+	layout := worker.timeLayout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return Parsers{
+		timeType:     func(s string) (interface{}, error) { return time.Parse(layout, s) },
+		durationType: func(s string) (interface{}, error) { return time.ParseDuration(s) },
+		ipType: func(s string) (interface{}, error) {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("%q is not a valid IP", s)
+			}
+			return ip, nil
+		},
+		ipNetType: func(s string) (interface{}, error) {
+			_, ipNet, err := net.ParseCIDR(s)
+			if err != nil {
+				return nil, err
+			}
+			return *ipNet, nil
+		},
+		urlType: func(s string) (interface{}, error) { return url.Parse(s) },
+		bigIntType: func(s string) (interface{}, error) {
+			n, ok := new(big.Int).SetString(s, 10)
+			if !ok {
+				return nil, fmt.Errorf("%q is not a valid integer", s)
+			}
+			return n, nil
+		},
+	}
+}
+
+// parserFor returns the parser to use for fieldType, a user-registered one
+// taking priority over the package's built-ins. A built-in parser is not
+// returned for a type that has a decoder installed via RegisterDecoder, so
+// that decoder applies instead.
+func (worker *Worker) parserFor(fieldType reflect.Type) (func(string) (interface{}, error), bool) {
+	if parse, ok := worker.Parsers[fieldType]; ok {
+		return parse, true
+	}
+	if _, ok := registeredDecoder(fieldType); ok {
+		return nil, false
+	}
+	parse, ok := worker.builtinParsers()[fieldType]
+	return parse, ok
+}
+
+// applyCustomConversion tries, in order, a Worker's own Parsers entry (see
+// WithParser), the package-level decoder registry (see RegisterDecoder), a
+// built-in parser, and the encoding.TextUnmarshaler/BinaryUnmarshaler
+// interfaces for fieldType. handled reports whether one of them claimed the
+// conversion (err may still be non-nil); when handled is false the caller
+// should fall back to its own type switch. fieldType may itself be a
+// pointer type (e.g. *big.Int): convertScalar calls this with the field's
+// declared type first, and only then unwraps a pointer field and calls it
+// again with the pointee type.
+func (worker *Worker) applyCustomConversion(reflectValue reflect.Value, fieldType reflect.Type, raw string) (handled bool, err error) {
+	if parse, ok := worker.parserFor(fieldType); ok {
+		parsed, err := parse(raw)
+		if err != nil {
+			return true, err
+		}
+		parsedValue := reflect.ValueOf(parsed)
+		if !parsedValue.Type().AssignableTo(fieldType) {
+			return true, fmt.Errorf("parser for %s returned a %s value", fieldType, parsedValue.Type())
+		}
+		reflectValue.Set(parsedValue)
+		return true, nil
+	}
+
+	if decode, ok := registeredDecoder(fieldType); ok {
+		parsedValue, err := decode(raw)
+		if err != nil {
+			return true, err
+		}
+		if !parsedValue.Type().AssignableTo(fieldType) {
+			return true, fmt.Errorf("decoder for %s returned a %s value", fieldType, parsedValue.Type())
+		}
+		reflectValue.Set(parsedValue)
+		return true, nil
+	}
+
+	if reflect.PtrTo(fieldType).Implements(textUnmarshalerType) {
+		ptr := reflect.New(fieldType)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return true, err
+		}
+		reflectValue.Set(ptr.Elem())
+		return true, nil
+	}
+
+	if reflect.PtrTo(fieldType).Implements(binaryUnmarshalerType) {
+		ptr := reflect.New(fieldType)
+		if err := ptr.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte(raw)); err != nil {
+			return true, err
+		}
+		reflectValue.Set(ptr.Elem())
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,104 @@
+package remarshal
+
+import (
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestBuiltinTimeParser(t *testing.T) {
+	v := &struct {
+		When time.Time
+	}{}
+	err := RegexUnmarshalWith(
+		"2021-05-01T10:00:00Z",
+		regexp.MustCompile(`^(?P<When>.*)$`),
+		v,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	if !v.When.Equal(want) {
+		t.Fatalf("got %v, want %v", v.When, want)
+	}
+}
+
+func TestBuiltinIPParser(t *testing.T) {
+	v := &struct {
+		Addr net.IP
+	}{}
+	err := RegexUnmarshalWith(
+		"127.0.0.1",
+		regexp.MustCompile(`^(?P<Addr>.*)$`),
+		v,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Addr.String() != "127.0.0.1" {
+		t.Fatalf("got %v", v.Addr)
+	}
+}
+
+func TestBuiltinBigIntParser(t *testing.T) {
+	v := &struct {
+		Num *big.Int
+	}{}
+	err := RegexUnmarshal(
+		"12345",
+		regexp.MustCompile(`^(?P<Num>\d+)$`),
+		v,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Num == nil || v.Num.String() != "12345" {
+		t.Fatalf("got %v", v.Num)
+	}
+}
+
+func TestBuiltinURLParser(t *testing.T) {
+	v := &struct {
+		Link *url.URL
+	}{}
+	err := RegexUnmarshal(
+		"https://example.com/path",
+		regexp.MustCompile(`^(?P<Link>.*)$`),
+		v,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Link == nil || v.Link.String() != "https://example.com/path" {
+		t.Fatalf("got %v", v.Link)
+	}
+}
+
+func TestWithParser(t *testing.T) {
+	type Celsius float64
+	v := &struct {
+		Temp Celsius
+	}{}
+	err := RegexUnmarshalWith(
+		"hot",
+		regexp.MustCompile(`^(?P<Temp>.*)$`),
+		v,
+		WithParser(reflect.TypeOf(Celsius(0)), func(s string) (interface{}, error) {
+			if s == "hot" {
+				return Celsius(100), nil
+			}
+			return Celsius(0), nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Temp != 100 {
+		t.Fatalf("got %v", v.Temp)
+	}
+}
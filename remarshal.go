@@ -1,6 +1,6 @@
 /*
-Package remarshal uses regex patterns in order to unpack strings into struct properties
-and the other way around, in the future.
+Package remarshal uses regex patterns in order to unpack strings into struct properties,
+and the other way around via Marshal/RegexMarshal.
 */
 package remarshal
 
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
-	"strconv"
 	"strings"
 	"text/template"
 
@@ -24,6 +23,22 @@ type field struct {
 	reflect.StructField
 	tagValue         *string
 	tagIsSetManually *bool
+
+	// path is the chain of struct-field indices from the root value down to
+	// this field, needed to reach fields nested inside named structs.
+	path []int
+	// dottedName is the dot-joined path of tag-or-field names down to this
+	// field (e.g. "Addr.City"), used for error messages and String().
+	dottedName string
+	// split, when non-empty, is the separator a slice field's captured
+	// string should be split on (regex_group:"Name,split=,").
+	split string
+	// required, optional, hasDefault and defaultValue mirror tagOptions;
+	// see parseTagOptions.
+	required     bool
+	optional     bool
+	hasDefault   bool
+	defaultValue string
 }
 
 type regexValue struct {
@@ -47,6 +62,25 @@ type Worker struct {
 	Changes          []*change
 	ExtraFields      []*field
 	ExtraRegexGroups []string
+	// RepeatedValues holds every match's value for each regex group, keyed
+	// by group name, when the pattern matched more than once. Only slice
+	// fields without a split tag consume it; see ApplyChanges.
+	RepeatedValues map[string][]string
+
+	// maxMatches bounds how many times the regex may match repeatedly, set
+	// via WithMaxMatches. Zero means unlimited.
+	maxMatches int
+
+	// Parsers holds user-registered conversions, set via WithParser. They
+	// take priority over the package's built-in parsers.
+	Parsers Parsers
+	// timeLayout overrides the layout used by the built-in time.Time
+	// parser; set via WithTimeLayout. Defaults to time.RFC3339.
+	timeLayout string
+
+	// Validator, set via WithValidator, checks each converted value
+	// against its `validate:"..."` tag before it's assigned.
+	Validator Validator
 }
 
 var workerTemplate *template.Template
@@ -91,41 +125,123 @@ func (field *field) isTagSetManually() bool {
 	return *field.tagIsSetManually
 }
 
-// Lookup for interesting fields
+// lookupFields returns the regex_group metadata for typeOf, backed by the
+// package-level type cache so the reflect walk below only ever runs once
+// per struct type.
 func lookupFields(typeOf reflect.Type) (fields []*field, err error) {
-	// parsing of fields
+	info := structInfoFor(typeOf)
+	return info.fields, info.err
+}
+
+// computeFields performs the actual reflect.Type walk; its result is cached
+// per type by structInfoFor. It descends into nested named structs and
+// pointers to structs, qualifying their regex_group tags with a dotted path
+// (e.g. "Addr.City" for the regex group "Addr_City"), guarding against
+// cyclic type references along the way. An anonymous (embedded) struct field
+// without its own regex_group tag is promoted into its parent's namespace
+// instead of being qualified, matching how Go itself promotes embedded
+// fields; tagging the embedded field explicitly opts it back into normal
+// nested-field qualification.
+func computeFields(typeOf reflect.Type) (fields []*field, err error) {
+	return computeFieldsRecursive(typeOf, nil, "", "", map[reflect.Type]bool{typeOf: true})
+}
+
+func computeFieldsRecursive(typeOf reflect.Type, parentPath []int, groupPrefix, namePrefix string, visited map[reflect.Type]bool) (fields []*field, err error) {
 	for i := 0; i < typeOf.NumField(); i++ {
-		field := makeField(typeOf.Field(i))
-		if existingField := field.isAmong(fields); existingField != nil {
-			if existingField.isTagSetManually() && field.isTagSetManually() { // conflict
+		sf := typeOf.Field(i)
+		path := append(append([]int{}, parentPath...), i)
+		f := makeField(sf, path, groupPrefix, namePrefix)
+
+		nestedType := sf.Type
+		if nestedType.Kind() == reflect.Ptr {
+			nestedType = nestedType.Elem()
+		}
+		if nestedType.Kind() == reflect.Struct && isNestableStruct(sf.Type) {
+			if visited[nestedType] {
+				return nil, fmt.Errorf("regex_group: cyclic struct reference involving type %s", nestedType)
+			}
+			visited[nestedType] = true
+			nestedGroupPrefix, nestedNamePrefix := f.GetTagValue(), f.dottedName
+			if sf.Anonymous && !f.isTagSetManually() {
+				nestedGroupPrefix, nestedNamePrefix = groupPrefix, namePrefix
+			}
+			nested, nestedErr := computeFieldsRecursive(nestedType, path, nestedGroupPrefix, nestedNamePrefix, visited)
+			delete(visited, nestedType)
+			if nestedErr != nil {
+				return nil, nestedErr
+			}
+			if fields, err = mergeFields(fields, nested); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if fields, err = mergeFields(fields, []*field{f}); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// mergeFields folds toAdd into fields, applying the same tag-priority and
+// conflict-detection rules as a flat struct walk would.
+func mergeFields(fields []*field, toAdd []*field) ([]*field, error) {
+	for _, f := range toAdd {
+		if existingField := f.isAmong(fields); existingField != nil {
+			if existingField.isTagSetManually() && f.isTagSetManually() { // conflict
 				return nil, fmt.Errorf(`regex group "%s" can't point to both "%s" and "%s"`,
 					existingField.GetTagValue(),
-					existingField.Name,
-					field.Name,
+					existingField.dottedName,
+					f.dottedName,
 				)
 			}
-			if !existingField.isTagSetManually() && field.isTagSetManually() {
-				existingField.impersonate(field)
+			if !existingField.isTagSetManually() && f.isTagSetManually() {
+				existingField.impersonate(f)
 			}
 		} else {
-			fields = append(fields, field)
+			fields = append(fields, f)
 		}
 	}
-	return
+	return fields, nil
 }
+
 func (field *field) impersonate(targetField *field) {
 	field.StructField = targetField.StructField
 	field.tagValue = targetField.tagValue
 	field.tagIsSetManually = targetField.tagIsSetManually
+	field.path = targetField.path
+	field.dottedName = targetField.dottedName
+	field.split = targetField.split
+	field.required = targetField.required
+	field.optional = targetField.optional
+	field.hasDefault = targetField.hasDefault
+	field.defaultValue = targetField.defaultValue
 }
 
-func makeField(f reflect.StructField) *field {
-	field := &field{f, nil, nil}
-	field.lookupTagIfNeeded()
-	if *field.tagValue == "" {
-		field.tagValue = &field.Name
+// makeField builds the field metadata for sf, found at path from the root
+// struct. groupPrefix/namePrefix carry the enclosing nested struct's
+// already-resolved regex group / dotted display name, if any.
+func makeField(sf reflect.StructField, path []int, groupPrefix, namePrefix string) *field {
+	raw, setManually := sf.Tag.Lookup(StructTag)
+	opts := parseTagOptions(raw)
+	name := opts.name
+	if name == "" {
+		name = sf.Name
+	}
+
+	group := joinGroup(groupPrefix, name)
+	return &field{
+		StructField:      sf,
+		tagValue:         &group,
+		tagIsSetManually: &setManually,
+		path:             path,
+		dottedName:       joinDotted(namePrefix, name),
+		split:            opts.split,
+		required:         opts.required,
+		optional:         opts.optional,
+		hasDefault:       opts.hasDefault,
+		defaultValue:     opts.defaultValue,
 	}
-	return field
 }
 
 // Returns the existing field or nil
@@ -150,34 +266,48 @@ func validate(v interface{}) (*reflect.Value, error) {
 	return &valueOf, nil
 }
 
-// Computes the regex string map (group => value)
-// The error is returned when there was no match
-func stringToValues(data string, re *regexp.Regexp) (values []*regexValue, err error) {
-	match := re.FindStringSubmatch(data)
-	if match == nil {
-		return nil, errors.New("no regex match")
+// stringToValues computes the regex string map (group => value) for the
+// first match, same as before, plus a group => every match's value map
+// (repeated) for when the pattern matches data more than once, which slice
+// fields without a split tag use to accumulate one element per match. A
+// single match yields a nil repeated map, so existing non-slice behavior is
+// unaffected. maxMatches bounds how many times the regex is matched (0
+// means unlimited); the error is returned when there was no match at all.
+func stringToValues(data string, re *regexp.Regexp, maxMatches int) (values []*regexValue, repeated map[string][]string, err error) {
+	n := -1
+	if maxMatches > 0 {
+		n = maxMatches
+	}
+	matches := re.FindAllStringSubmatch(data, n)
+	if matches == nil {
+		return nil, nil, errors.New("no regex match")
 	}
+
 	reGroups := re.SubexpNames()[1:]
-	for i, value := range match[1:] {
+	for i, value := range matches[0][1:] {
 		values = append(values, &regexValue{
 			Group: reGroups[i],
 			Value: value,
 		})
 	}
+
+	if len(matches) > 1 {
+		repeated = make(map[string][]string, len(reGroups))
+		for _, match := range matches {
+			for i, value := range match[1:] {
+				repeated[reGroups[i]] = append(repeated[reGroups[i]], value)
+			}
+		}
+	}
 	return
 }
 
-func getExtraRegexGroups(fields []*field, values []*regexValue) (extra []string) {
+// getExtraRegexGroups uses the cached tag-value -> field index for an O(1)
+// lookup per regex group, instead of scanning fields for every value.
+func getExtraRegexGroups(byTag map[string]*field, values []*regexValue) (extra []string) {
 	extra = []string{}
 	for _, value := range values {
-		match := false
-		for _, field := range fields {
-			if field.GetTagValue() == value.Group {
-				match = true
-				break
-			}
-		}
-		if !match {
+		if _, ok := byTag[value.Group]; !ok {
 			extra = append(extra, value.Group)
 		}
 	}
@@ -185,131 +315,124 @@ func getExtraRegexGroups(fields []*field, values []*regexValue) (extra []string)
 }
 
 func getExtraTags(fields []*field, values []*regexValue) (extra []*field) {
+	matched := make(map[string]bool, len(values))
+	for _, value := range values {
+		matched[value.Group] = true
+	}
 	for _, field := range fields {
-		match := false
-		for _, value := range values {
-			if field.GetTagValue() == value.Group {
-				match = true
-			}
-		}
-		if !match && *field.tagIsSetManually {
+		if !matched[field.GetTagValue()] && field.isTagSetManually() {
 			extra = append(extra, field)
 		}
 	}
 	return
 }
 
-func getChanges(fields []*field, values []*regexValue) (changes []*change) {
+// getChanges uses the cached tag-value -> field index for an O(1) lookup
+// per regex value, instead of scanning fields for every value.
+func getChanges(byTag map[string]*field, values []*regexValue) (changes []*change) {
 	for _, value := range values {
-		for _, field := range fields {
-			if field.GetTagValue() == value.Group {
-				changes = append(changes, &change{
-					ReValue: value,
-					Field:   field,
-				})
-			}
+		if field, ok := byTag[value.Group]; ok {
+			changes = append(changes, &change{
+				ReValue: value,
+				Field:   field,
+			})
 		}
 	}
 	return
 }
 
-// ApplyChanges sets the computed value changeset on the struct
+// ApplyChanges sets the computed value changeset on the struct, walking each
+// field's path to reach fields nested inside named/pointer structs and
+// allocating nil pointers along the way.
 func (worker *Worker) ApplyChanges() (errs []error) {
-	value := worker.reflectValue.Elem()
 	for _, change := range worker.Changes {
-		reflectValue := value.FieldByName(change.Field.Name)
+		reflectValue, err := worker.fieldValue(change.Field.path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
 		if !reflectValue.CanSet() {
 			errs = append(errs, fmt.Errorf("can't set value '%s' for field '%s'",
 				change.ReValue.Value,
-				change.Field.Name,
+				change.Field.dottedName,
 			))
 			continue
 		}
 
 		newValue := change.ReValue.Value
-		fieldType, _ := value.Type().FieldByName(change.Field.Name)
-		dataType := fieldType.Type.Kind()
-
-		switch dataType {
-		case reflect.String:
-			reflectValue.SetString(newValue)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			converted, err := strconv.ParseInt(newValue, 0, strconv.IntSize)
-			if err != nil {
-				errStr := "value '%s' of regex group '%s' can't be converted to int in order to be assigned to field '%s'"
-				errs = append(errs, fmt.Errorf(errStr,
-					change.ReValue.Value,
-					change.ReValue.Group,
-					change.Field.Name,
-				))
-				continue
-			}
-			reflectValue.SetInt(converted)
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			converted, err := strconv.ParseUint(newValue, 0, strconv.IntSize)
-			if err != nil {
-				errStr := "value '%s' of regex group '%s' can't be converted to int in order to be assigned to field '%s'"
-				errs = append(errs, fmt.Errorf(errStr,
+		fieldType := change.Field.Type
+		// converted is only assigned to the field once it passes validation
+		converted := reflect.New(fieldType).Elem()
+
+		if fieldType.Kind() == reflect.Slice && change.Field.split != "" {
+			if err := worker.setSliceFromSplit(converted, fieldType, newValue, change.Field.split); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"value '%s' of regex group '%s' can't populate slice field '%s': %w",
 					change.ReValue.Value,
 					change.ReValue.Group,
-					change.Field.Name,
+					change.Field.dottedName,
+					err,
 				))
 				continue
 			}
-			reflectValue.SetUint(converted)
-		case reflect.Float32, reflect.Float64:
-			converted, err := strconv.ParseFloat(newValue, 0)
-			if err != nil {
-				errStr := "value '%s' of regex group '%s' can't be converted to float in order to be assigned to field '%s'"
-				errs = append(errs, fmt.Errorf(errStr,
-					change.ReValue.Value,
+		} else if fieldType.Kind() == reflect.Slice && len(worker.RepeatedValues[change.ReValue.Group]) > 0 {
+			if err := worker.setSliceFromValues(converted, fieldType, worker.RepeatedValues[change.ReValue.Group]); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"repeated matches of regex group '%s' can't populate slice field '%s': %w",
 					change.ReValue.Group,
-					change.Field.Name,
+					change.Field.dottedName,
+					err,
 				))
 				continue
 			}
-			reflectValue.SetFloat(converted)
-		case reflect.Bool:
-			converted, err := strconv.ParseBool(newValue)
-			if err != nil {
-				errStr := "value '%s' of regex group '%s' can't be converted to bool in order to be assigned to field '%s'"
-				errs = append(errs, fmt.Errorf(errStr,
-					change.ReValue.Value,
-					change.ReValue.Group,
-					change.Field.Name,
-				))
-				continue
-			}
-			reflectValue.SetBool(converted)
-		default:
-			errStr := "%s field's type '%s' unknown, can't assign value '%s' corresponding to regex group '%s'"
-			errs = append(errs, fmt.Errorf(errStr,
-				change.Field.Name,
-				dataType.String(),
+		} else if err := worker.convertScalar(converted, fieldType, newValue); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"value '%s' of regex group '%s' can't be converted for field '%s': %w",
 				change.ReValue.Value,
 				change.ReValue.Group,
+				change.Field.dottedName,
+				err,
 			))
+			continue
 		}
+
+		if err := worker.validateChange(change.Field.StructField, converted); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reflectValue.Set(converted)
 	}
 	return
 }
 
-// NewWorker instantiates the Worker type, which implements the RegexUnmarshaler interface
-func NewWorker(text string, re *regexp.Regexp, v interface{}) (w *Worker, errs []error) {
+// validateChange runs the Worker's Validator, if any, against value.
+func (worker *Worker) validateChange(sf reflect.StructField, value reflect.Value) error {
+	if worker.Validator == nil {
+		return nil
+	}
+	return worker.Validator.Validate(sf, value.Interface())
+}
+
+// NewWorker instantiates the Worker type, which implements the RegexUnmarshaler interface.
+// opts can customize the Worker before the match is applied, e.g. WithParser or WithTimeLayout.
+func NewWorker(text string, re *regexp.Regexp, v interface{}, opts ...Option) (w *Worker, errs []error) {
 	var err error
 	w = &Worker{}
+	for _, opt := range opts {
+		opt(w)
+	}
 
 	w.reflectValue, err = validate(v)
 	if err != nil {
 		errs = append(errs, err)
 		return // v is not a pointer to a struct
 	}
-	fields, err := lookupFields(w.reflectValue.Elem().Type())
-	if err != nil {
+	info := structInfoFor(w.reflectValue.Elem().Type())
+	if info.err != nil {
 		// 2 or more tags point to the same re group
-		errs = append(errs, err)
+		errs = append(errs, info.err)
 	}
-	values, err := stringToValues(text, re)
+	values, repeated, err := stringToValues(text, re, w.maxMatches)
 	if err != nil {
 		// no match
 		errs = append(errs, err)
@@ -317,22 +440,43 @@ func NewWorker(text string, re *regexp.Regexp, v interface{}) (w *Worker, errs [
 
 	w.V = &v
 	w.Values = values
-	w.Fields = fields
+	w.RepeatedValues = repeated
+	w.Fields = info.fields
 
 	// these are ok, as the user might reuse the regex pattern
-	w.ExtraRegexGroups = getExtraRegexGroups(w.Fields, w.Values)
+	w.ExtraRegexGroups = getExtraRegexGroups(info.byTag, w.Values)
 
-	// not ok, check your struct tags pls
+	// not ok, check your struct tags pls, unless the field opted out via
+	// ",optional" or supplied a ",default=..." to fall back on
 	w.ExtraFields = getExtraTags(w.Fields, w.Values)
 	for _, extraField := range w.ExtraFields {
+		if extraField.hasDefault {
+			w.Changes = append(w.Changes, &change{
+				Field:   extraField,
+				ReValue: &regexValue{Group: *extraField.tagValue, Value: extraField.defaultValue},
+			})
+			continue
+		}
+		if extraField.optional {
+			continue
+		}
 		errs = append(errs, fmt.Errorf(
 			".%s `%s` not found in your pattern",
-			extraField.Name,
+			extraField.dottedName,
 			*extraField.tagValue,
 		))
 	}
 
-	w.Changes = getChanges(w.Fields, w.Values)
+	w.Changes = append(w.Changes, getChanges(info.byTag, w.Values)...)
+	for _, c := range w.Changes {
+		if c.Field.required && c.ReValue.Value == "" {
+			errs = append(errs, fmt.Errorf(
+				"regex group '%s' for field '%s' is required but matched an empty value",
+				c.ReValue.Group,
+				c.Field.dottedName,
+			))
+		}
+	}
 
 	// displayed by String()
 	w.Text = text
@@ -351,7 +495,7 @@ func (worker *Worker) String() string {
 }
 
 func (field *field) String() string {
-	return fmt.Sprintf("%d. %s `%s`", field.Index[0]+1, field.Name, *field.tagValue)
+	return fmt.Sprintf("%d. %s `%s`", field.Index[0]+1, field.dottedName, *field.tagValue)
 }
 
 func (v *regexValue) String() string {
@@ -378,3 +522,15 @@ func RegexUnmarshal(text string, re *regexp.Regexp, v interface{}) error {
 	}
 	return multierror.Append(multiError, worker.ApplyChanges()...).ErrorOrNil()
 }
+
+// RegexUnmarshalWith is RegexUnmarshal's configurable sibling, accepting
+// functional options such as WithParser and WithTimeLayout.
+func RegexUnmarshalWith(text string, re *regexp.Regexp, v interface{}, opts ...Option) error {
+	var multiError *multierror.Error
+	worker, errs := NewWorker(text, re, v, opts...)
+	if len(errs) > 0 {
+		// these should be validation errors, so fatal, so let's return
+		return multierror.Append(multiError, errs...)
+	}
+	return multierror.Append(multiError, worker.ApplyChanges()...).ErrorOrNil()
+}
@@ -0,0 +1,55 @@
+package remarshal
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestSliceFieldFromRepeatedMatches(t *testing.T) {
+	v := &struct {
+		Tag []string
+	}{}
+	re := regexp.MustCompile(`(?P<Tag>\w+);?`)
+
+	if err := RegexUnmarshal("red;green;blue", re, v); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"red", "green", "blue"}
+	if len(v.Tag) != len(want) {
+		t.Fatalf("got %v, want %v", v.Tag, want)
+	}
+	for i := range want {
+		if v.Tag[i] != want[i] {
+			t.Fatalf("got %v, want %v", v.Tag, want)
+		}
+	}
+}
+
+func TestSliceFieldFromRepeatedMatchesRespectsMaxMatches(t *testing.T) {
+	v := &struct {
+		Tag []string
+	}{}
+	re := regexp.MustCompile(`(?P<Tag>\w+);?`)
+
+	if err := RegexUnmarshalWith("red;green;blue", re, v, WithMaxMatches(2)); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"red", "green"}
+	if len(v.Tag) != len(want) {
+		t.Fatalf("got %v, want %v", v.Tag, want)
+	}
+}
+
+func TestNonSliceFieldTakesFirstMatchWhenRepeated(t *testing.T) {
+	v := &struct {
+		Tag string
+	}{}
+	re := regexp.MustCompile(`(?P<Tag>\w+);?`)
+
+	if err := RegexUnmarshal("red;green;blue", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Tag != "red" {
+		t.Fatalf("got %q, want %q", v.Tag, "red")
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"testing"
 )
 
-func TestDataTypes(t *testing.T) {
+func TestRegexUnmarshalBasicTypes(t *testing.T) {
 	v := &struct {
 		String  string
 		Bool    bool
@@ -25,7 +25,7 @@ func TestDataTypes(t *testing.T) {
 	}
 }
 
-func TestBasicFunctionality(t *testing.T) {
+func TestRegexUnmarshalTagOverridesFieldName(t *testing.T) {
 	v := &struct {
 		Smth     string `regex_group:"Something"`
 		SmthElse string
@@ -39,7 +39,7 @@ func TestBasicFunctionality(t *testing.T) {
 	}
 }
 
-func TestInvalidStructTag(t *testing.T) {
+func TestRegexUnmarshalUnknownGroupErrors(t *testing.T) {
 	err := RegexUnmarshal(
 		"a|b",
 		regexp.MustCompile(`^(?P<SomethingElse>.*)\|(?P<Something>.*)$`),
@@ -52,7 +52,7 @@ func TestInvalidStructTag(t *testing.T) {
 	}
 }
 
-func TestCrossingTag(t *testing.T) {
+func TestRegexUnmarshalTagTakesPriorityOverFieldName(t *testing.T) {
 	v := &struct {
 		Something string
 		Smth      string `regex_group:"Something"`
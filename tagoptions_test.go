@@ -0,0 +1,60 @@
+package remarshal
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestOptionalFieldMissingFromPattern(t *testing.T) {
+	v := &struct {
+		Name string `regex_group:"Name"`
+		Nick string `regex_group:"Nick,optional"`
+	}{}
+	re := regexp.MustCompile(`^(?P<Name>.*)$`)
+
+	if err := RegexUnmarshal("Bob", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "Bob" || v.Nick != "" {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestRequiredFieldMissingFromPatternErrors(t *testing.T) {
+	v := &struct {
+		Name string `regex_group:"Name,required"`
+	}{}
+	re := regexp.MustCompile(`^x$`)
+
+	err := RegexUnmarshal("x", re, v)
+	if err == nil || !strings.Contains(err.Error(), "not found in your pattern") {
+		t.Fatalf("expected a not-found error, got %v", err)
+	}
+}
+
+func TestRequiredFieldMatchedEmptyErrors(t *testing.T) {
+	v := &struct {
+		Name string `regex_group:"Name,required"`
+	}{}
+	re := regexp.MustCompile(`^(?P<Name>.*)$`)
+
+	err := RegexUnmarshal("", re, v)
+	if err == nil || !strings.Contains(err.Error(), "is required") {
+		t.Fatalf("expected a required-but-empty error, got %v", err)
+	}
+}
+
+func TestDefaultValueAppliedWhenMissingFromPattern(t *testing.T) {
+	v := &struct {
+		Env string `regex_group:"Env,default=production"`
+	}{}
+	re := regexp.MustCompile(`^x$`)
+
+	if err := RegexUnmarshal("x", re, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Env != "production" {
+		t.Fatalf("got %q, want %q", v.Env, "production")
+	}
+}
@@ -0,0 +1,119 @@
+package remarshal
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validator checks a freshly-converted value against field's struct tags
+// before it's assigned, e.g. a `validate:"required,min=1"` tag.
+type Validator interface {
+	Validate(field reflect.StructField, value interface{}) error
+}
+
+// WithValidator installs v as the Worker's Validator. Every converted value
+// is passed through it before assignment; failures are aggregated into the
+// same multierror RegexUnmarshal/RegexUnmarshalWith return.
+func WithValidator(v Validator) Option {
+	return func(w *Worker) {
+		w.Validator = v
+	}
+}
+
+// DefaultValidator is a small, dependency-free Validator covering the most
+// common `validate:"..."` checks: required, min/max (numeric value or
+// string/slice length), oneof=, and regexp=. For the full rule set, see
+// NewPlaygroundValidator.
+type DefaultValidator struct{}
+
+// ValidateTag is the struct tag DefaultValidator and NewPlaygroundValidator
+// read their rules from.
+const ValidateTag = "validate"
+
+func (DefaultValidator) Validate(field reflect.StructField, value interface{}) error {
+	tag, ok := field.Tag.Lookup(ValidateTag)
+	if !ok || tag == "" {
+		return nil
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		if err := validateRule(field, value, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRule(field reflect.StructField, value interface{}, rule string) error {
+	name, arg := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		name, arg = rule[:i], rule[i+1:]
+	}
+
+	switch name {
+	case "required":
+		if isZeroValue(value) {
+			return fmt.Errorf("field '%s' is required", field.Name)
+		}
+	case "oneof":
+		options := strings.Fields(arg)
+		for _, opt := range options {
+			if fmt.Sprint(value) == opt {
+				return nil
+			}
+		}
+		return fmt.Errorf("field '%s' must be one of %q", field.Name, options)
+	case "min":
+		return validateBound(field, value, arg, false)
+	case "max":
+		return validateBound(field, value, arg, true)
+	case "regexp":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return fmt.Errorf("field '%s' has an invalid regexp validation rule: %w", field.Name, err)
+		}
+		if !re.MatchString(fmt.Sprint(value)) {
+			return fmt.Errorf("field '%s' doesn't match pattern %q", field.Name, arg)
+		}
+	}
+	return nil
+}
+
+func isZeroValue(value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	return !rv.IsValid() || rv.IsZero()
+}
+
+// validateBound checks a numeric value, or the length of a string/slice,
+// against arg, enforcing a maximum when isMax is true and a minimum
+// otherwise (mirroring validator's shared min/max semantics).
+func validateBound(field reflect.StructField, value interface{}, arg string, isMax bool) error {
+	rv := reflect.ValueOf(value)
+	var n float64
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice:
+		n = float64(rv.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = rv.Float()
+	default:
+		return nil
+	}
+
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("field '%s' has an invalid bound %q: %w", field.Name, arg, err)
+	}
+	if isMax && n > bound {
+		return fmt.Errorf("field '%s' must be at most %s", field.Name, arg)
+	}
+	if !isMax && n < bound {
+		return fmt.Errorf("field '%s' must be at least %s", field.Name, arg)
+	}
+	return nil
+}
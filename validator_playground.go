@@ -0,0 +1,32 @@
+package remarshal
+
+import (
+	"fmt"
+	"reflect"
+
+	playgroundvalidator "github.com/go-playground/validator/v10"
+)
+
+// NewPlaygroundValidator adapts a *validator.Validate from
+// github.com/go-playground/validator/v10 to the Validator interface,
+// running the target field's existing `validate:"..."` tag through it.
+// Use this when DefaultValidator's small rule set (required, min/max,
+// oneof, regexp) isn't enough.
+func NewPlaygroundValidator(v *playgroundvalidator.Validate) Validator {
+	return playgroundAdapter{v: v}
+}
+
+type playgroundAdapter struct {
+	v *playgroundvalidator.Validate
+}
+
+func (a playgroundAdapter) Validate(field reflect.StructField, value interface{}) error {
+	tag, ok := field.Tag.Lookup(ValidateTag)
+	if !ok || tag == "" {
+		return nil
+	}
+	if err := a.v.Var(value, tag); err != nil {
+		return fmt.Errorf("field '%s' failed validation: %w", field.Name, err)
+	}
+	return nil
+}
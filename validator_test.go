@@ -0,0 +1,52 @@
+package remarshal
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDefaultValidatorRequired(t *testing.T) {
+	v := &struct {
+		Name string `validate:"required"`
+	}{}
+	err := RegexUnmarshalWith(
+		"",
+		regexp.MustCompile(`^(?P<Name>.*)$`),
+		v,
+		WithValidator(DefaultValidator{}),
+	)
+	if err == nil || !strings.Contains(err.Error(), "is required") {
+		t.Fatalf("expected a required-field error, got %v", err)
+	}
+}
+
+func TestDefaultValidatorMinMax(t *testing.T) {
+	v := &struct {
+		Age int `validate:"min=0,max=120"`
+	}{}
+	err := RegexUnmarshalWith(
+		"200",
+		regexp.MustCompile(`^(?P<Age>.*)$`),
+		v,
+		WithValidator(DefaultValidator{}),
+	)
+	if err == nil || !strings.Contains(err.Error(), "at most") {
+		t.Fatalf("expected a max-bound error, got %v", err)
+	}
+}
+
+func TestDefaultValidatorOneOf(t *testing.T) {
+	v := &struct {
+		Color string `validate:"oneof=red green blue"`
+	}{}
+	err := RegexUnmarshalWith(
+		"purple",
+		regexp.MustCompile(`^(?P<Color>.*)$`),
+		v,
+		WithValidator(DefaultValidator{}),
+	)
+	if err == nil || !strings.Contains(err.Error(), "must be one of") {
+		t.Fatalf("expected a oneof error, got %v", err)
+	}
+}